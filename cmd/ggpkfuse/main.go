@@ -0,0 +1,44 @@
+// Command ggpkfuse mounts a Path of Exile Content.ggpk as a read-only
+// FUSE filesystem.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/vitaminmoo/pogo/poefs/ggpk"
+	"github.com/vitaminmoo/pogo/poefs/ggpkfuse"
+)
+
+func main() {
+	flag.Usage = func() {
+		log.Printf("usage: %s <Content.ggpk> <mountpoint>", os.Args[0])
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	g, err := ggpk.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("failed to open GGPK: %v", err)
+	}
+
+	server, err := ggpkfuse.Mount(g, flag.Arg(1))
+	if err != nil {
+		log.Fatalf("failed to mount: %v", err)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		<-sig
+		server.Unmount()
+	}()
+
+	server.Wait()
+}
@@ -0,0 +1,276 @@
+// Package fstest is a shared, backend-agnostic behavioral test suite
+// for anything implementing io/fs.FS in this repo, in the spirit of
+// go-fuse's posixtest package: write the checks once, run them against
+// every backend (ggpk-backed, bundles2-backed, ...) so new ones get
+// validated for free.
+package fstest
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+// Options tunes RunAll for backends whose lookup semantics differ from
+// a plain case-sensitive POSIX filesystem.
+type Options struct {
+	// CaseInsensitive marks a backend (such as ggpk- or
+	// bundles2-backed filesystems, which key their directory index by
+	// a lowercased name hash) as resolving paths regardless of case.
+	CaseInsensitive bool
+}
+
+// RunAll exercises mk() against the standard io/fs contract: ReadDir,
+// WalkDir, Sub, Glob, partial ReadDir(n) pagination, fs.ErrNotExist
+// propagation, optional case-insensitive lookup, and io.ReaderAt/Seeker
+// behavior on regular files. mk is called once per subtest so a test
+// case that inadvertently advances or mutates state (seeks, partial
+// reads) never leaks into the next one.
+func RunAll(t *testing.T, mk func() fs.FS, opts Options) {
+	t.Helper()
+
+	t.Run("ReadDir", func(t *testing.T) { testReadDir(t, mk()) })
+	t.Run("WalkDir", func(t *testing.T) { testWalkDir(t, mk()) })
+	t.Run("Sub", func(t *testing.T) { testSub(t, mk()) })
+	t.Run("Glob", func(t *testing.T) { testGlob(t, mk()) })
+	t.Run("ReadDirPagination", func(t *testing.T) { testReadDirPagination(t, mk()) })
+	t.Run("ErrNotExist", func(t *testing.T) { testErrNotExist(t, mk()) })
+	t.Run("ReaderAtSeeker", func(t *testing.T) { testReaderAtSeeker(t, mk()) })
+	if opts.CaseInsensitive {
+		t.Run("CaseInsensitiveLookup", func(t *testing.T) { testCaseInsensitiveLookup(t, mk()) })
+	}
+}
+
+// firstFile returns the path of some regular file reachable from root,
+// for subtests that need any file but don't care which one.
+func firstFile(t *testing.T, fsys fs.FS) string {
+	t.Helper()
+	var path string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && path == "" {
+			path = p
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+	if path == "" {
+		t.Skip("filesystem under test has no regular files")
+	}
+	return path
+}
+
+func testReadDir(t *testing.T, fsys fs.FS) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatalf("ReadDir(.): %v", err)
+	}
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if seen[e.Name()] {
+			t.Errorf("ReadDir(.) returned duplicate entry %q", e.Name())
+		}
+		seen[e.Name()] = true
+		if _, err := e.Info(); err != nil {
+			t.Errorf("entry %q Info(): %v", e.Name(), err)
+		}
+	}
+}
+
+func testWalkDir(t *testing.T, fsys fs.FS) {
+	if err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		return err
+	}); err != nil {
+		t.Errorf("WalkDir(.): %v", err)
+	}
+}
+
+func testSub(t *testing.T, fsys fs.FS) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatalf("ReadDir(.): %v", err)
+	}
+	var dir string
+	for _, e := range entries {
+		if e.IsDir() {
+			dir = e.Name()
+			break
+		}
+	}
+	if dir == "" {
+		t.Skip("filesystem under test has no subdirectories")
+	}
+
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		t.Fatalf("Sub(%q): %v", dir, err)
+	}
+	if _, err := fs.ReadDir(sub, "."); err != nil {
+		t.Errorf("ReadDir(.) on Sub(%q): %v", dir, err)
+	}
+}
+
+func testGlob(t *testing.T, fsys fs.FS) {
+	matches, err := fs.Glob(fsys, "*")
+	if err != nil {
+		t.Fatalf("Glob(*): %v", err)
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatalf("ReadDir(.): %v", err)
+	}
+	if len(matches) != len(entries) {
+		t.Errorf("Glob(*) returned %d matches, ReadDir(.) has %d entries", len(matches), len(entries))
+	}
+}
+
+// testReadDirPagination checks that repeated ReadDir(n) calls on the
+// same directory handle return every entry exactly once and that the
+// final short read reports io.EOF, the behavior fsPdirNode.ReadDir is
+// prone to regressing since it tracks its offset by hand.
+func testReadDirPagination(t *testing.T, fsys fs.FS) {
+	f, err := fsys.Open(".")
+	if err != nil {
+		t.Fatalf("Open(.): %v", err)
+	}
+	defer f.Close()
+
+	rdf, ok := f.(fs.ReadDirFile)
+	if !ok {
+		t.Skip("root does not implement fs.ReadDirFile")
+	}
+
+	all, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatalf("ReadDir(.): %v", err)
+	}
+	if len(all) == 0 {
+		t.Skip("filesystem under test has an empty root")
+	}
+
+	f2, err := fsys.Open(".")
+	if err != nil {
+		t.Fatalf("Open(.): %v", err)
+	}
+	defer f2.Close()
+	rdf2 := f2.(fs.ReadDirFile)
+
+	var paged []fs.DirEntry
+	for {
+		batch, err := rdf2.ReadDir(1)
+		paged = append(paged, batch...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadDir(1): %v", err)
+		}
+		if len(batch) == 0 {
+			t.Fatalf("ReadDir(1) returned no entries and no error before exhausting the directory")
+		}
+	}
+
+	if len(paged) != len(all) {
+		t.Errorf("paginated ReadDir(1) returned %d entries, want %d", len(paged), len(all))
+	}
+	seen := make(map[string]bool, len(paged))
+	for _, e := range paged {
+		if seen[e.Name()] {
+			t.Errorf("paginated ReadDir(1) returned duplicate entry %q", e.Name())
+		}
+		seen[e.Name()] = true
+	}
+
+	_ = rdf // root's first handle is only used to confirm the interface
+}
+
+func testErrNotExist(t *testing.T, fsys fs.FS) {
+	_, err := fsys.Open("this-path-should-not-exist-in-any-fixture")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open(nonexistent): got %v, want fs.ErrNotExist", err)
+	}
+}
+
+func testReaderAtSeeker(t *testing.T, fsys fs.FS) {
+	path := firstFile(t, fsys)
+
+	f, err := fsys.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", path, err)
+	}
+	defer f.Close()
+
+	want, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("ReadAll(%q): %v", path, err)
+	}
+	if len(want) == 0 {
+		t.Skip("first file found is empty, nothing to exercise ReaderAt/Seeker against")
+	}
+
+	f2, err := fsys.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", path, err)
+	}
+	defer f2.Close()
+
+	ra, ok := f2.(io.ReaderAt)
+	if !ok {
+		t.Skip("file does not implement io.ReaderAt")
+	}
+	got := make([]byte, len(want))
+	if _, err := ra.ReadAt(got, 0); err != nil && err != io.EOF {
+		t.Fatalf("ReadAt(0): %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("ReadAt(0) content mismatch")
+	}
+
+	seeker, ok := f2.(io.Seeker)
+	if !ok {
+		t.Skip("file does not implement io.Seeker")
+	}
+	if _, err := seeker.Seek(int64(len(want)/2), io.SeekStart); err != nil {
+		t.Fatalf("Seek(middle): %v", err)
+	}
+	tail, err := io.ReadAll(f2)
+	if err != nil {
+		t.Fatalf("ReadAll after Seek: %v", err)
+	}
+	if string(tail) != string(want[len(want)/2:]) {
+		t.Errorf("content after Seek(middle) mismatch")
+	}
+}
+
+// testCaseInsensitiveLookup checks that a backend which advertises
+// CaseInsensitive resolves a path regardless of the case used to name
+// it, the behavior ggpk's ChildNamed gets from hashing the lowercased,
+// UTF-16 encoded name.
+func testCaseInsensitiveLookup(t *testing.T, fsys fs.FS) {
+	path := firstFile(t, fsys)
+
+	upper := toUpperASCII(path)
+	if upper == path {
+		t.Skip("first file found has no letters to case-fold")
+	}
+
+	if _, err := fs.Stat(fsys, upper); err != nil {
+		t.Errorf("Stat(%q) (case-folded from %q): %v", upper, path, err)
+	}
+}
+
+func toUpperASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'a' && c <= 'z' {
+			b[i] = c - ('a' - 'A')
+		}
+	}
+	return string(b)
+}
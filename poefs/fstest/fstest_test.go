@@ -0,0 +1,39 @@
+package fstest
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRunAllAgainstMapFS(t *testing.T) {
+	mk := func() fs.FS {
+		return fstest.MapFS{
+			"Data/Metadata.txt": {Data: []byte("hello world")},
+			"Data/empty.txt":    {Data: []byte{}},
+			"Art/icon.png":      {Data: []byte{0x89, 0x50, 0x4e, 0x47}},
+		}
+	}
+	RunAll(t, mk, Options{})
+}
+
+// caseFoldedFS wraps a MapFS keyed by lowercase paths and resolves Open
+// regardless of the case it's called with, standing in for a ggpk- or
+// bundles2-backed filesystem without needing a real fixture.
+type caseFoldedFS fstest.MapFS
+
+func (c caseFoldedFS) Open(name string) (fs.File, error) {
+	return fstest.MapFS(c).Open(strings.ToLower(name))
+}
+
+func TestRunAllAgainstCaseFoldedFS(t *testing.T) {
+	mk := func() fs.FS {
+		return caseFoldedFS{
+			"data/metadata.txt": {Data: []byte("hello world")},
+			"data/empty.txt":    {Data: []byte{}},
+			"art/icon.png":      {Data: []byte{0x89, 0x50, 0x4e, 0x47}},
+		}
+	}
+	RunAll(t, mk, Options{CaseInsensitive: true})
+}
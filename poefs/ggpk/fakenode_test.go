@@ -0,0 +1,78 @@
+package ggpk
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// fakeFileNode and fakeDirNode implement anyNode/dirNode without a real
+// ggpkFS, so manifest/verify logic can be tested against a small
+// in-memory tree instead of a live GGPK.
+
+type fakeFileNode struct {
+	name string
+	data []byte
+	sig  []byte
+}
+
+func (n *fakeFileNode) Name() string  { return n.name }
+func (n *fakeFileNode) Offset() int64 { return 0 }
+
+func (n *fakeFileNode) Reader() (fs.File, error) {
+	return &fakeFile{Reader: bytes.NewReader(n.data), stat: fakeFileInfo{name: n.name, size: int64(len(n.data)), sig: n.sig}}, nil
+}
+
+type fakeFile struct {
+	io.Reader
+	stat fakeFileInfo
+}
+
+func (f *fakeFile) Close() error               { return nil }
+func (f *fakeFile) Stat() (fs.FileInfo, error) { return f.stat, nil }
+
+type fakeFileInfo struct {
+	name string
+	size int64
+	sig  []byte
+}
+
+func (fi fakeFileInfo) Name() string       { return fi.name }
+func (fi fakeFileInfo) Size() int64        { return fi.size }
+func (fi fakeFileInfo) Mode() fs.FileMode  { return 0o444 }
+func (fi fakeFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fakeFileInfo) IsDir() bool        { return false }
+func (fi fakeFileInfo) Sys() any           { return nil }
+func (fi fakeFileInfo) Signature() []byte  { return fi.sig }
+
+type fakeDirNode struct {
+	name     string
+	sig      []byte
+	children []anyNode
+}
+
+func (n *fakeDirNode) Name() string      { return n.name }
+func (n *fakeDirNode) Offset() int64     { return 0 }
+func (n *fakeDirNode) Signature() []byte { return n.sig }
+func (n *fakeDirNode) Children() ([]anyNode, error) {
+	return n.children, nil
+}
+
+func (n *fakeDirNode) ChildNamed(name string) (anyNode, error) {
+	for _, c := range n.children {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fs.ErrNotExist
+}
+
+func (n *fakeDirNode) Reader() (fs.File, error) {
+	return nil, fs.ErrInvalid
+}
+
+var (
+	_ anyNode = (*fakeFileNode)(nil)
+	_ dirNode = (*fakeDirNode)(nil)
+)
@@ -0,0 +1,30 @@
+package ggpk_test
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/vitaminmoo/pogo/poefs/fstest"
+	"github.com/vitaminmoo/pogo/poefs/ggpk"
+)
+
+// TestFSConformance wires the shared fstest.RunAll suite up against a
+// real Content.ggpk, the same posixtest-style smoke test bundles2-backed
+// filesystems should get once that backend exists. It's opt-in via
+// POGO_TEST_GGPK since a GGPK fixture is too large to vendor into the
+// repo.
+func TestFSConformance(t *testing.T) {
+	path := os.Getenv("POGO_TEST_GGPK")
+	if path == "" {
+		t.Skip("set POGO_TEST_GGPK to a Content.ggpk path to run the fs.FS conformance suite against it")
+	}
+
+	fstest.RunAll(t, func() fs.FS {
+		g, err := ggpk.Open(path)
+		if err != nil {
+			t.Fatalf("ggpk.Open(%q): %v", path, err)
+		}
+		return g
+	}, fstest.Options{CaseInsensitive: true})
+}
@@ -0,0 +1,221 @@
+package ggpk
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// manifestEntry is one line of a manifest: a file's path relative to the
+// manifest root, its size, the signature of the PDIR it lives in, and
+// the file's own content hash.
+type manifestEntry struct {
+	path       string
+	size       int64
+	pdirSig    []byte
+	fileSHA256 []byte
+}
+
+func (e manifestEntry) String() string {
+	return fmt.Sprintf("%s\t%d\t%s\t%s", e.path, e.size, hex.EncodeToString(e.pdirSig), hex.EncodeToString(e.fileSHA256))
+}
+
+// MarshalManifest returns a manifest (see WriteManifest) of every file
+// reachable from root, each path prefixed with prefix.
+func (g *ggpkFS) MarshalManifest(prefix string) (string, error) {
+	var sb strings.Builder
+	if err := g.WriteManifest(&sb, prefix); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// Size returns the total data size, in bytes, of every FILE node
+// reachable from root, or 0 if the tree couldn't be fully walked.
+func (g *ggpkFS) Size() int64 {
+	root, err := g.Root()
+	if err != nil {
+		return 0
+	}
+	var total int64
+	if err := walkManifestTree(root, "", nil, func(e manifestEntry) error {
+		total += e.size
+		return nil
+	}); err != nil {
+		return 0
+	}
+	return total
+}
+
+// WriteManifest streams one "path size pdir-sig file-sha256" line per
+// file to w, writing as it walks rather than buffering the whole tree.
+// Entries appear in tree walk order, not sorted by path.
+func (g *ggpkFS) WriteManifest(w io.Writer, prefix string) error {
+	root, err := g.Root()
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := walkManifestTree(root, prefix, nil, func(e manifestEntry) error {
+		_, err := fmt.Fprintln(bw, e.String())
+		return err
+	}); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// walkManifestTree calls visit once per FILE node under n. parentSig is
+// the signature of the immediately containing PDIR.
+func walkManifestTree(n anyNode, root string, parentSig []byte, visit func(manifestEntry) error) error {
+	if dn, ok := n.(dirNode); ok {
+		children, err := dn.Children()
+		if err != nil {
+			return fmt.Errorf("failed to list %q: %w", root, err)
+		}
+		for _, c := range children {
+			childPath := c.Name()
+			if root != "" {
+				childPath = root + "/" + childPath
+			}
+			if err := walkManifestTree(c, childPath, dn.Signature(), visit); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	f, err := n.Reader()
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", root, err)
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %q: %w", root, err)
+	}
+	var sig []byte
+	if s, ok := stat.(signer); ok {
+		sig = s.Signature()
+	}
+	return visit(manifestEntry{
+		path:       root,
+		size:       stat.Size(),
+		pdirSig:    parentSig,
+		fileSHA256: sig,
+	})
+}
+
+// signer mirrors fsPdirNodeStat.Signature.
+type signer interface {
+	Signature() []byte
+}
+
+// Mismatch describes one path where a manifest and the live GGPK tree
+// disagree.
+type Mismatch struct {
+	Path   string
+	Reason MismatchReason
+}
+
+// MismatchReason enumerates why a manifest entry didn't match.
+type MismatchReason int
+
+const (
+	// Added means the path exists in the GGPK but not in the manifest.
+	Added MismatchReason = iota
+	// Removed means the path exists in the manifest but not the GGPK.
+	Removed
+	// Changed means the path exists in both but its size or hash
+	// differs.
+	Changed
+)
+
+func (r MismatchReason) String() string {
+	switch r {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// VerifyManifest re-reads the current GGPK tree and compares it against
+// a manifest previously produced by MarshalManifest/WriteManifest,
+// reporting every path that was added, removed, or changed. Tree paths
+// are compared without a prefix, so against a manifest written with a
+// non-empty prefix every entry is reported as removed and every file as
+// added.
+func (g *ggpkFS) VerifyManifest(r io.Reader) ([]Mismatch, error) {
+	root, err := g.Root()
+	if err != nil {
+		return nil, err
+	}
+	return verifyManifestAgainst(root, r)
+}
+
+// verifyManifestAgainst is VerifyManifest's logic taking the tree root
+// directly, so it can be exercised in tests without a real ggpkFS.
+func verifyManifestAgainst(root anyNode, r io.Reader) ([]Mismatch, error) {
+	want := make(map[string]manifestEntry)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("ggpk: malformed manifest line %q", line)
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ggpk: malformed manifest size %q: %w", fields[1], err)
+		}
+		fileSHA256, err := hex.DecodeString(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("ggpk: malformed manifest hash %q: %w", fields[3], err)
+		}
+		want[fields[0]] = manifestEntry{path: fields[0], size: size, fileSHA256: fileSHA256}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	got := make(map[string]manifestEntry)
+	if err := walkManifestTree(root, "", nil, func(e manifestEntry) error {
+		got[e.path] = e
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var mismatches []Mismatch
+	for path, w := range want {
+		g, ok := got[path]
+		if !ok {
+			mismatches = append(mismatches, Mismatch{Path: path, Reason: Removed})
+			continue
+		}
+		if g.size != w.size || hex.EncodeToString(g.fileSHA256) != hex.EncodeToString(w.fileSHA256) {
+			mismatches = append(mismatches, Mismatch{Path: path, Reason: Changed})
+		}
+	}
+	for path := range got {
+		if _, ok := want[path]; !ok {
+			mismatches = append(mismatches, Mismatch{Path: path, Reason: Added})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].Path < mismatches[j].Path })
+	return mismatches, nil
+}
@@ -0,0 +1,111 @@
+package ggpk
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+)
+
+func sha256Of(data []byte) []byte {
+	h := sha256.Sum256(data)
+	return h[:]
+}
+
+// foldSig computes a PDIR signature the same way verifyDir does: sha256
+// of its children's signatures concatenated in order.
+func foldSig(childSigs ...[]byte) []byte {
+	h := sha256.New()
+	for _, s := range childSigs {
+		h.Write(s)
+	}
+	return h.Sum(nil)
+}
+
+func buildTestTree() anyNode {
+	hello := []byte("hello world")
+	bSig := sha256Of([]byte("b"))
+	aSig := sha256Of(hello)
+	dirSig := foldSig(bSig)
+	return &fakeDirNode{
+		name: "",
+		sig:  foldSig(aSig, dirSig),
+		children: []anyNode{
+			&fakeFileNode{name: "a.txt", data: hello, sig: aSig},
+			&fakeDirNode{
+				name: "dir",
+				sig:  dirSig,
+				children: []anyNode{
+					&fakeFileNode{name: "b.txt", data: []byte("b"), sig: bSig},
+				},
+			},
+		},
+	}
+}
+
+func TestVerifyManifestAgainstClassifiesChanges(t *testing.T) {
+	root := buildTestTree()
+
+	var manifest strings.Builder
+	if err := walkManifestTree(root, "", nil, func(e manifestEntry) error {
+		_, err := manifest.WriteString(e.String() + "\n")
+		return err
+	}); err != nil {
+		t.Fatalf("walkManifestTree: %v", err)
+	}
+
+	// Remove "a.txt" from the manifest (so it shows up as added), and
+	// swap in a stale entry for a path the tree no longer has (removed).
+	lines := strings.Split(strings.TrimRight(manifest.String(), "\n"), "\n")
+	var kept []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "a.txt\t") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	kept = append(kept, manifestEntry{path: "gone.txt", size: 1, fileSHA256: sha256Of([]byte("x"))}.String())
+
+	mismatches, err := verifyManifestAgainst(root, strings.NewReader(strings.Join(kept, "\n")))
+	if err != nil {
+		t.Fatalf("verifyManifestAgainst: %v", err)
+	}
+
+	got := make(map[string]MismatchReason, len(mismatches))
+	for _, m := range mismatches {
+		got[m.Path] = m.Reason
+	}
+
+	if r, ok := got["a.txt"]; !ok || r != Added {
+		t.Errorf("a.txt: got %v (reported=%v), want Added", r, ok)
+	}
+	if got["gone.txt"] != Removed {
+		t.Errorf("gone.txt: got %v, want Removed", got["gone.txt"])
+	}
+	if _, ok := got["dir/b.txt"]; ok {
+		t.Errorf("dir/b.txt: unexpectedly reported as a mismatch")
+	}
+}
+
+func TestVerifyManifestAgainstDetectsChangedContent(t *testing.T) {
+	root := buildTestTree()
+
+	var manifest strings.Builder
+	if err := walkManifestTree(root, "", nil, func(e manifestEntry) error {
+		_, err := manifest.WriteString(e.String() + "\n")
+		return err
+	}); err != nil {
+		t.Fatalf("walkManifestTree: %v", err)
+	}
+
+	// Mutate a.txt's on-tree content after the manifest was taken.
+	dir := root.(*fakeDirNode)
+	dir.children[0] = &fakeFileNode{name: "a.txt", data: []byte("goodbye world"), sig: sha256Of([]byte("goodbye world"))}
+
+	mismatches, err := verifyManifestAgainst(root, strings.NewReader(manifest.String()))
+	if err != nil {
+		t.Fatalf("verifyManifestAgainst: %v", err)
+	}
+	if len(mismatches) != 1 || mismatches[0].Path != "a.txt" || mismatches[0].Reason != Changed {
+		t.Errorf("got %+v, want a single Changed mismatch for a.txt", mismatches)
+	}
+}
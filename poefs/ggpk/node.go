@@ -0,0 +1,35 @@
+package ggpk
+
+import "io/fs"
+
+// Node is the external view of a tree node (PDIR or FILE), for packages
+// outside ggpk (e.g. ggpkfuse) that can't name the concrete types.
+type Node interface {
+	Name() string
+	Reader() (fs.File, error)
+	Offset() int64
+}
+
+// anyNode is an alias, not a distinct type: it must stay the exact same
+// interface as Node so a value returned as anyNode still satisfies
+// ggpk.Node outside this package.
+type anyNode = Node
+
+// dirNode is the subset of *pdirNode that manifest/verify walks need,
+// kept as an interface so they can be driven by fakes in tests instead
+// of a real ggpkFS-backed tree.
+type dirNode interface {
+	anyNode
+	Children() ([]anyNode, error)
+	ChildNamed(name string) (anyNode, error)
+	Signature() []byte
+}
+
+// FS aliases the unexported GGPK type so external packages can hold one
+// without naming it.
+type FS = ggpkFS
+
+// Root returns the tree root as a Node.
+func (g *ggpkFS) Root() (Node, error) {
+	return g.getNodeAt(g.rootOffset)
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf16"
 
@@ -16,6 +18,7 @@ import (
 type pdirNode struct {
 	src       *ggpkFS
 	name      string
+	offset    int64
 	signature []byte
 	children  []pdirChild
 }
@@ -29,6 +32,14 @@ func (n *pdirNode) Name() string {
 	return n.name
 }
 
+func (n *pdirNode) Offset() int64 {
+	return n.offset
+}
+
+func (n *pdirNode) Signature() []byte {
+	return n.signature
+}
+
 func (g *ggpkFS) newPdirNode(data []byte, offset int64, length uint32) (*pdirNode, error) {
 	if len(data) < 40 {
 		return nil, errNodeTooShort
@@ -59,6 +70,7 @@ func (g *ggpkFS) newPdirNode(data []byte, offset int64, length uint32) (*pdirNod
 	n := &pdirNode{
 		src:       g,
 		name:      name,
+		offset:    offset,
 		signature: signature,
 		children:  make([]pdirChild, childCount),
 	}
@@ -78,6 +90,11 @@ func (g *ggpkFS) newPdirNode(data []byte, offset int64, length uint32) (*pdirNod
 		}
 	}
 
+	// children on disk aren't required to be sorted by hash
+	sort.Slice(n.children, func(i, j int) bool {
+		return n.children[i].hash < n.children[j].hash
+	})
+
 	return n, nil
 }
 
@@ -93,32 +110,66 @@ func (n *pdirNode) Children() ([]anyNode, error) {
 	return children, nil
 }
 
-func (n *pdirNode) ChildNamed(name string) (anyNode, error) {
+// nameHashCache memoizes name -> murmur hash across PDIRs
+var nameHashCache sync.Map // map[string]uint32
+
+func nameHash(name string) uint32 {
+	if h, ok := nameHashCache.Load(name); ok {
+		return h.(uint32)
+	}
 	codepoints := utf16.Encode([]rune(strings.ToLower(name)))
-	var cp []byte
-	buf := new(bytes.Buffer)
-	for _, c := range codepoints {
-		err := binary.Write(buf, binary.LittleEndian, c)
+	cp := make([]byte, 2*len(codepoints))
+	for i, c := range codepoints {
+		binary.LittleEndian.PutUint16(cp[2*i:], c)
+	}
+	h := murmur.MurmurHash2(cp, 0x0)
+	nameHashCache.Store(name, h)
+	return h
+}
+
+func (n *pdirNode) ChildNamed(name string) (anyNode, error) {
+	h := nameHash(name)
+	lo, hi := n.hashRange(h)
+	for _, c := range n.children[lo:hi] {
+		cn, err := n.src.getNodeAt(c.offset)
 		if err != nil {
 			return nil, err
 		}
-	}
-	cp = buf.Bytes()
-	h := murmur.MurmurHash2(cp, 0x0)
-	for i := range n.children {
-		if n.children[i].hash == h {
-			cn, err := n.src.getNodeAt(n.children[i].offset)
-			if err != nil {
-				return nil, err
-			}
-			if cn.Name() == name {
-				return cn, nil
-			}
+		if strings.EqualFold(cn.Name(), name) {
+			return cn, nil
 		}
 	}
 	return nil, fs.ErrNotExist
 }
 
+// LookupHash resolves every child whose hash equals h directly, for
+// callers that already have the hash and skip name encoding.
+func (n *pdirNode) LookupHash(h uint32) ([]anyNode, error) {
+	lo, hi := n.hashRange(h)
+	nodes := make([]anyNode, 0, hi-lo)
+	for _, c := range n.children[lo:hi] {
+		cn, err := n.src.getNodeAt(c.offset)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, cn)
+	}
+	return nodes, nil
+}
+
+// hashRange returns the [lo, hi) bounds of n.children with hash == h;
+// hi > lo+1 only on a 32-bit hash collision between distinct names.
+func (n *pdirNode) hashRange(h uint32) (int, int) {
+	lo := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].hash >= h
+	})
+	hi := lo
+	for hi < len(n.children) && n.children[hi].hash == h {
+		hi++
+	}
+	return lo, hi
+}
+
 func (n *pdirNode) Reader() (fs.File, error) {
 	return &fsPdirNode{n, 0}, nil
 }
@@ -0,0 +1,221 @@
+package ggpk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// VerifyOptions configures a (*ggpkFS).Verify walk.
+type VerifyOptions struct {
+	// Concurrency caps how many nodes are processed by their own
+	// goroutine at once; beyond the cap, a child is verified inline.
+	// Zero means runtime.GOMAXPROCS(0).
+	Concurrency int
+	// Prefix restricts the walk to the PDIR/FILE reachable at this
+	// path, rather than the whole tree. Empty means the whole tree.
+	Prefix string
+	// AbortOnMismatch stops spawning new work as soon as one mismatch
+	// is seen, rather than walking the rest of the tree.
+	AbortOnMismatch bool
+}
+
+// VerifyResult is one path's outcome from a (*ggpkFS).Verify walk. For a
+// PDIR, Want/Got are the stored vs. recomputed directory signature; for
+// a FILE, they're the stored vs. recomputed content SHA256.
+type VerifyResult struct {
+	Path string
+	OK   bool
+	Want []byte
+	Got  []byte
+	Err  error
+}
+
+var errVerifyAborted = errors.New("ggpk: verify aborted on first mismatch")
+
+// Verify walks the tree rooted at opts.Prefix (or the whole tree),
+// recomputing each node's signature, and streams one VerifyResult per
+// path. The returned channel is closed when the walk finishes.
+func (g *ggpkFS) Verify(ctx context.Context, opts VerifyOptions) (<-chan VerifyResult, error) {
+	root, err := g.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	target := anyNode(root)
+	startPath := ""
+	if opts.Prefix != "" {
+		target, err = resolvePath(root, opts.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		startPath = opts.Prefix
+	}
+
+	return verifyTree(ctx, target, startPath, opts), nil
+}
+
+// verifyTree is Verify's walk taking the starting node directly, so it
+// can be exercised in tests without a real ggpkFS.
+func verifyTree(ctx context.Context, target anyNode, startPath string, opts VerifyOptions) <-chan VerifyResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	v := &verifier{
+		opts:    opts,
+		results: make(chan VerifyResult),
+		sem:     make(chan struct{}, concurrency),
+	}
+
+	go func() {
+		defer close(v.results)
+		v.walk(ctx, target, startPath)
+	}()
+
+	return v.results
+}
+
+// resolvePath walks name-by-name ("a/b/c") from n down to the node at
+// prefix, the same path-component model used by the mounted fs.FS view.
+func resolvePath(n anyNode, prefix string) (anyNode, error) {
+	cur := n
+	for _, part := range splitPath(prefix) {
+		dir, ok := cur.(dirNode)
+		if !ok {
+			return nil, fmt.Errorf("ggpk: %q is not a directory", prefix)
+		}
+		child, err := dir.ChildNamed(part)
+		if err != nil {
+			return nil, err
+		}
+		cur = child
+	}
+	return cur, nil
+}
+
+func splitPath(p string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(p); i++ {
+		if p[i] == '/' {
+			if i > start {
+				parts = append(parts, p[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(p) {
+		parts = append(parts, p[start:])
+	}
+	return parts
+}
+
+type verifier struct {
+	opts    VerifyOptions
+	results chan VerifyResult
+	sem     chan struct{}
+	aborted atomic.Bool
+}
+
+func (v *verifier) emit(r VerifyResult) {
+	v.results <- r
+	if !r.OK && v.opts.AbortOnMismatch {
+		v.aborted.Store(true)
+	}
+}
+
+// walk recomputes n's signature, emits its VerifyResult, and returns the
+// recomputed signature so a parent PDIR can fold it into its own.
+func (v *verifier) walk(ctx context.Context, n anyNode, path string) []byte {
+	if err := ctx.Err(); err != nil {
+		v.emit(VerifyResult{Path: path, Err: err})
+		return nil
+	}
+	if v.aborted.Load() {
+		v.emit(VerifyResult{Path: path, Err: errVerifyAborted})
+		return nil
+	}
+
+	if dn, ok := n.(dirNode); ok {
+		return v.verifyDir(ctx, dn, path)
+	}
+	return v.verifyFile(n, path)
+}
+
+func (v *verifier) verifyFile(n anyNode, path string) []byte {
+	f, err := n.Reader()
+	if err != nil {
+		v.emit(VerifyResult{Path: path, Err: err})
+		return nil
+	}
+	defer f.Close()
+
+	var want []byte
+	if stat, err := f.Stat(); err == nil {
+		if s, ok := stat.(signer); ok {
+			want = s.Signature()
+		}
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		v.emit(VerifyResult{Path: path, Err: err})
+		return nil
+	}
+	got := h.Sum(nil)
+
+	v.emit(VerifyResult{Path: path, OK: bytes.Equal(got, want), Want: want, Got: got})
+	return got
+}
+
+func (v *verifier) verifyDir(ctx context.Context, n dirNode, path string) []byte {
+	children, err := n.Children()
+	if err != nil {
+		v.emit(VerifyResult{Path: path, Err: err})
+		return nil
+	}
+
+	sigs := make([][]byte, len(children))
+
+	var wg sync.WaitGroup
+	for i, c := range children {
+		i, c := i, c
+		childPath := c.Name()
+		if path != "" {
+			childPath = path + "/" + childPath
+		}
+
+		// Non-blocking acquire: a blocking one would deadlock once
+		// the tree is deeper than Concurrency levels.
+		select {
+		case v.sem <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-v.sem }()
+				sigs[i] = v.walk(ctx, c, childPath)
+			}()
+		default:
+			sigs[i] = v.walk(ctx, c, childPath)
+		}
+	}
+	wg.Wait()
+
+	h := sha256.New()
+	for _, sig := range sigs {
+		h.Write(sig)
+	}
+	got := h.Sum(nil)
+
+	sig := n.Signature()
+	v.emit(VerifyResult{Path: path, OK: bytes.Equal(got, sig), Want: sig, Got: got})
+	return got
+}
@@ -0,0 +1,66 @@
+package ggpk
+
+import (
+	"context"
+	"testing"
+)
+
+func collectVerify(t *testing.T, root anyNode, opts VerifyOptions) map[string]VerifyResult {
+	t.Helper()
+	results := make(map[string]VerifyResult)
+	for r := range verifyTree(context.Background(), root, "", opts) {
+		results[r.Path] = r
+	}
+	return results
+}
+
+func TestVerifyTreeAllOK(t *testing.T) {
+	root := buildTestTree()
+
+	results := collectVerify(t, root, VerifyOptions{})
+
+	for _, path := range []string{"a.txt", "dir/b.txt", "dir", ""} {
+		r, ok := results[path]
+		if !ok {
+			t.Fatalf("no VerifyResult for %q", path)
+		}
+		if !r.OK {
+			t.Errorf("%q: got OK=false, want true (err=%v)", path, r.Err)
+		}
+	}
+}
+
+func TestVerifyTreeReportsFileMismatch(t *testing.T) {
+	root := buildTestTree()
+	dir := root.(*fakeDirNode)
+	dir.children[0] = &fakeFileNode{name: "a.txt", data: []byte("tampered"), sig: sha256Of([]byte("hello world"))}
+
+	results := collectVerify(t, root, VerifyOptions{})
+
+	if r := results["a.txt"]; r.OK {
+		t.Errorf("a.txt: got OK=true, want false for tampered content")
+	}
+	if r := results["dir/b.txt"]; !r.OK {
+		t.Errorf("dir/b.txt: got OK=false, want true (unaffected sibling)")
+	}
+}
+
+func TestVerifyTreePrefix(t *testing.T) {
+	root := buildTestTree()
+	sub, err := resolvePath(root, "dir")
+	if err != nil {
+		t.Fatalf("resolvePath(dir): %v", err)
+	}
+
+	results := make(map[string]VerifyResult)
+	for r := range verifyTree(context.Background(), sub, "dir", VerifyOptions{}) {
+		results[r.Path] = r
+	}
+
+	if _, ok := results["a.txt"]; ok {
+		t.Errorf("verifyTree with a prefix walked outside it and reported a.txt")
+	}
+	if r, ok := results["dir/b.txt"]; !ok || !r.OK {
+		t.Errorf("dir/b.txt: got %+v, ok=%v, want OK=true", r, ok)
+	}
+}
@@ -0,0 +1,59 @@
+// Package ggpkfuse exposes an already-loaded GGPK as a mountable,
+// read-only FUSE filesystem via github.com/hanwen/go-fuse/v2/fs.
+//
+// The tree is mounted lazily: a directory's children are only turned
+// into *fs.Inode nodes the first time they're looked up or listed, the
+// same "materialize on demand" model used by go-fuse's zipfs and statfs
+// examples. There is no writeback; the mount is strictly read-only.
+package ggpkfuse
+
+import (
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/vitaminmoo/pogo/poefs/ggpk"
+)
+
+// Options configures a Mount beyond go-fuse's own defaults.
+type Options struct {
+	fuseOpts *fs.Options
+}
+
+// Option mutates Options.
+type Option func(*Options)
+
+// WithFuseOptions overrides the go-fuse mount options (allow-other,
+// debug logging, etc.) that would otherwise default to a plain
+// read-only mount.
+func WithFuseOptions(o *fs.Options) Option {
+	return func(opts *Options) {
+		opts.fuseOpts = o
+	}
+}
+
+// Mount exposes g as a read-only FUSE filesystem at mountpoint and
+// returns the running *fuse.Server. Callers are responsible for calling
+// Wait (or Unmount) on the returned server.
+func Mount(g *ggpk.FS, mountpoint string, opts ...Option) (*fuse.Server, error) {
+	root, err := g.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Options{
+		fuseOpts: &fs.Options{},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.fuseOpts.MountOptions.Name = "ggpkfs"
+	o.fuseOpts.MountOptions.FsName = "ggpkfs"
+	o.fuseOpts.MountOptions.ReadOnly = true
+
+	rootNode := &ggpkNode{node: root}
+	server, err := fs.Mount(mountpoint, rootNode, o.fuseOpts)
+	if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
@@ -0,0 +1,235 @@
+package ggpkfuse
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"syscall"
+
+	gofusefs "github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/vitaminmoo/pogo/poefs/ggpk"
+)
+
+// signer and provenancer mirror the optional accessors ggpk attaches to
+// the fs.FileInfo returned by a node's Reader().Stat().
+type signer interface {
+	Signature() []byte
+}
+
+type provenancer interface {
+	Provenance() string
+}
+
+// ggpkNode wraps a ggpk.Node as a go-fuse InodeEmbedder. Children are
+// materialized lazily on Lookup/Readdir.
+type ggpkNode struct {
+	gofusefs.Inode
+
+	node ggpk.Node
+}
+
+var (
+	_ gofusefs.InodeEmbedder  = (*ggpkNode)(nil)
+	_ gofusefs.NodeLookuper   = (*ggpkNode)(nil)
+	_ gofusefs.NodeReaddirer  = (*ggpkNode)(nil)
+	_ gofusefs.NodeGetattrer  = (*ggpkNode)(nil)
+	_ gofusefs.NodeOpener     = (*ggpkNode)(nil)
+	_ gofusefs.NodeReader     = (*ggpkNode)(nil)
+	_ gofusefs.NodeGetxattrer = (*ggpkNode)(nil)
+)
+
+// stat returns the fs.FileInfo for the wrapped node.
+func (n *ggpkNode) stat() (fs.FileInfo, error) {
+	f, err := n.node.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
+
+// isDir reports whether the wrapped node is a directory, duck-typed off
+// whether its Reader() supports ReadDir.
+func (n *ggpkNode) isDir() bool {
+	return isDirNode(n.node)
+}
+
+// isDirNode is isDir for a ggpk.Node that hasn't been wrapped yet, for
+// use by Lookup before deciding whether to wrap the resolved child.
+func isDirNode(n ggpk.Node) bool {
+	f, err := n.Reader()
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	_, ok := f.(fs.ReadDirFile)
+	return ok
+}
+
+func stableAttr(n ggpk.Node, dir bool) gofusefs.StableAttr {
+	mode := uint32(fuse.S_IFREG)
+	if dir {
+		mode = fuse.S_IFDIR
+	}
+	return gofusefs.StableAttr{
+		Mode: mode,
+		Ino:  uint64(n.Offset()),
+	}
+}
+
+func (n *ggpkNode) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*gofusefs.Inode, syscall.Errno) {
+	child, err := childNode(n.node, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, syscall.ENOENT
+	}
+	if err != nil {
+		return nil, syscall.EIO
+	}
+
+	childDir := isDirNode(child)
+	out.Attr.Mode = 0o444
+	if childDir {
+		out.Attr.Mode |= fuse.S_IFDIR
+	} else {
+		out.Attr.Mode |= fuse.S_IFREG
+	}
+	return n.NewInode(ctx, &ggpkNode{node: child}, stableAttr(child, childDir)), 0
+}
+
+// childNode resolves name directly through the ChildNamed method of the
+// node's Reader() file (a PDIR's) rather than scanning a ReadDir(-1)
+// listing for a match.
+func childNode(n ggpk.Node, name string) (ggpk.Node, error) {
+	f, err := n.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dir, ok := f.(interface {
+		ChildNamed(string) (ggpk.Node, error)
+	})
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return dir.ChildNamed(name)
+}
+
+func (n *ggpkNode) Readdir(ctx context.Context) (gofusefs.DirStream, syscall.Errno) {
+	f, err := n.node.Reader()
+	if err != nil {
+		return nil, syscall.EIO
+	}
+	defer f.Close()
+
+	dir, ok := f.(fs.ReadDirFile)
+	if !ok {
+		return nil, syscall.ENOTDIR
+	}
+
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, syscall.EIO
+	}
+
+	dirEntries := make([]fuse.DirEntry, len(entries))
+	for i, e := range entries {
+		mode := uint32(fuse.S_IFREG)
+		if e.IsDir() {
+			mode = fuse.S_IFDIR
+		}
+		dirEntries[i] = fuse.DirEntry{Name: e.Name(), Mode: mode}
+	}
+	return gofusefs.NewListDirStream(dirEntries), 0
+}
+
+func (n *ggpkNode) Getattr(ctx context.Context, f gofusefs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	st, err := n.stat()
+	if err != nil {
+		return syscall.EIO
+	}
+	out.Attr.Mode = 0o444
+	if st.IsDir() {
+		out.Attr.Mode |= fuse.S_IFDIR
+	} else {
+		out.Attr.Mode |= fuse.S_IFREG
+		out.Attr.Size = uint64(st.Size())
+	}
+	return 0
+}
+
+func (n *ggpkNode) Open(ctx context.Context, flags uint32) (gofusefs.FileHandle, uint32, syscall.Errno) {
+	if n.isDir() {
+		return nil, 0, syscall.EISDIR
+	}
+	f, err := n.node.Reader()
+	if err != nil {
+		return nil, 0, syscall.EIO
+	}
+	return &fileHandle{f: f}, fuse.FOPEN_KEEP_CACHE, 0
+}
+
+func (n *ggpkNode) Read(ctx context.Context, f gofusefs.FileHandle, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	fh, ok := f.(*fileHandle)
+	if !ok {
+		return nil, syscall.EIO
+	}
+	ra, ok := fh.f.(interface {
+		ReadAt(p []byte, off int64) (int, error)
+	})
+	if !ok {
+		return nil, syscall.EIO
+	}
+	n2, err := ra.ReadAt(dest, off)
+	if err != nil && n2 == 0 {
+		return nil, syscall.EIO
+	}
+	return fuse.ReadResultData(dest[:n2]), 0
+}
+
+// Getxattr surfaces the node's signature and provenance as
+// user.ggpk.sha256 and user.ggpk.provenance respectively.
+func (n *ggpkNode) Getxattr(ctx context.Context, attr string, dest []byte) (uint32, syscall.Errno) {
+	st, err := n.stat()
+	if err != nil {
+		return 0, syscall.EIO
+	}
+
+	var val []byte
+	switch attr {
+	case "user.ggpk.sha256":
+		s, ok := st.(signer)
+		if !ok {
+			return 0, syscall.ENODATA
+		}
+		val = s.Signature()
+	case "user.ggpk.provenance":
+		p, ok := st.(provenancer)
+		if !ok {
+			return 0, syscall.ENODATA
+		}
+		val = []byte(p.Provenance())
+	default:
+		return 0, syscall.ENODATA
+	}
+
+	if len(dest) < len(val) {
+		return uint32(len(val)), syscall.ERANGE
+	}
+	copy(dest, val)
+	return uint32(len(val)), 0
+}
+
+// fileHandle holds the fs.File opened for a FILE node; Read uses that
+// file's own ReadAt and fails with EIO if it has none.
+type fileHandle struct {
+	f fs.File
+}
+
+var _ gofusefs.FileReleaser = (*fileHandle)(nil)
+
+func (fh *fileHandle) Release(ctx context.Context) syscall.Errno {
+	fh.f.Close()
+	return 0
+}